@@ -0,0 +1,300 @@
+package gobotlcd
+
+import (
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/i2c"
+)
+
+//Backend abstracts the physical transport used to drive the HD44780
+//controller, so GobotLCD can be wired to an I2C port expander or directly
+//to GPIO without changing any of the higher-level display logic.
+type Backend interface {
+	//Start establishes the backend's underlying connection, if any. It is
+	//called once by GobotLCD.Start.
+	Start() error
+
+	//Connection returns the gobot.Connection associated with the backend, to
+	//satisfy the gobot.Driver interface. Backends with no single shared
+	//connection, such as direct GPIO wiring, may return nil.
+	Connection() gobot.Connection
+
+	//WriteCommand sends a control byte to the HD44780 instruction register.
+	WriteCommand(b byte) error
+
+	//WriteData sends a byte to the HD44780 data register for display content.
+	WriteData(b byte) error
+
+	//SetBacklight turns the backlight line on or off.
+	SetBacklight(on bool) error
+
+	//Pulse toggles the enable line once against whatever is currently held
+	//on the data lines.
+	Pulse() error
+
+	//FourBitMode reports whether the backend addresses the HD44780 over a
+	//4-bit wide bus (two nibbles per byte) rather than an 8-bit one.
+	FourBitMode() bool
+}
+
+//BatchWriter is implemented by backends that can push a whole run of data
+//bytes to the HD44780 in a single transaction instead of one WriteData call
+//per byte. GobotLCD.Write uses it when available to cut down on the
+//syscalls and settle-time sleeps a long write otherwise incurs one byte at a
+//time. When WithEscapeSequences is enabled, Write does not use it and sends
+//each byte with its own WriteData call.
+type BatchWriter interface {
+	WriteDataBatch(b []byte) error
+}
+
+//BusyWaiter is implemented by backends that can poll the HD44780 busy flag,
+//letting GobotLCD replace a fixed settle-time sleep with an actual wait for
+//the controller to finish its current instruction.
+type BusyWaiter interface {
+	Busy() (bool, error)
+}
+
+//NibbleWriter is implemented by 4-bit backends and strobes a single raw
+//nibble onto D4-D7 with RS held low, without the second nibble WriteCommand
+//normally sends. GobotLCD's 4-bit "init by instruction" handshake needs
+//this: at that point the controller is still assuming 8-bit-style
+//decoding, so each bootstrap step must be exactly one nibble pulse rather
+//than the two-nibble split a full command byte gets once the controller is
+//actually in 4-bit mode.
+type NibbleWriter interface {
+	WriteNibble(nibble byte) error
+}
+
+// nibbleSettle is how long a single enable pulse needs to settle, matching
+// pulseEnable's post-low delay.
+const nibbleSettle = 50 * time.Microsecond
+
+//Polarity controls whether a backend's logical "on" state is expressed as a
+//high or low signal level, to support breakout boards that wire a line
+//inverted.
+type Polarity byte
+
+const (
+	//PositivePolarity drives a high signal for "on", the common case.
+	PositivePolarity Polarity = iota
+	//NegativePolarity drives a low signal for "on", for boards that wire the
+	//line inverted.
+	NegativePolarity
+)
+
+//I2C4BitBackend drives the HD44780 over a PCF8574 I2C port expander wired in
+//4-bit mode, which is how most off-the-shelf I2C backpacks are wired.
+type I2C4BitBackend struct {
+	connector         i2c.Connector
+	connection        i2c.Connection
+	backLight         byte
+	backlightPolarity Polarity
+	i2c.Config
+}
+
+//WithBacklightPolarity sets the backlight line's polarity, for PCF8574
+//boards whose backlight transistor is wired inverted.
+func WithBacklightPolarity(p Polarity) func(i2c.Config) {
+	return func(c i2c.Config) {
+		if b, ok := c.(*I2C4BitBackend); ok {
+			b.backlightPolarity = p
+		}
+	}
+}
+
+//NewI2C4BitBackend connects to an HD44780 through a PCF8574 I2C expander
+//wired in 4-bit mode.
+func NewI2C4BitBackend(a i2c.Connector, options ...func(i2c.Config)) *I2C4BitBackend {
+	ret := &I2C4BitBackend{
+		connector: a,
+		backLight: lcdNoBacklight,
+		Config:    i2c.NewConfig(),
+	}
+
+	for _, option := range options {
+		option(ret)
+	}
+
+	return ret
+}
+
+//Start connects to the I2C expander and runs the HD44780 power-on wait.
+func (b *I2C4BitBackend) Start() (err error) {
+	bus := b.GetBusOrDefault(1)
+	address := b.GetAddressOrDefault(0x27)
+
+	b.connection, err = b.connector.GetConnection(address, bus)
+	if err != nil {
+		return err
+	}
+
+	// LCD requires 40 ms after power-on before receiving commands
+	time.Sleep(50 * time.Millisecond)
+
+	if err = b.write(b.backLight); err != nil {
+		return err
+	}
+
+	time.Sleep(time.Second)
+
+	return nil
+}
+
+//Connection returns the I2C connection to the PCF8574 expander.
+func (b *I2C4BitBackend) Connection() gobot.Connection {
+	return b.connection.(gobot.Connection)
+}
+
+func (b *I2C4BitBackend) write(val byte) error {
+	return b.connection.WriteByte(val)
+}
+
+func (b *I2C4BitBackend) expandWrite(val byte) error {
+	return b.write(val | b.backLight)
+}
+
+//Pulse toggles the enable bit on the expander, leaving the backlight state
+//untouched.
+func (b *I2C4BitBackend) Pulse() error {
+	return b.pulseEnable(b.backLight)
+}
+
+func (b *I2C4BitBackend) pulseEnable(val byte) error {
+	if err := b.expandWrite(val | en); err != nil {
+		return err
+	}
+	time.Sleep(time.Microsecond)
+
+	if err := b.expandWrite(val & ^en); err != nil {
+		return err
+	}
+	time.Sleep(nibbleSettle)
+
+	return nil
+}
+
+func (b *I2C4BitBackend) write4bits(val byte) error {
+	if err := b.expandWrite(val); err != nil {
+		return err
+	}
+
+	return b.pulseEnable(val)
+}
+
+//WriteNibble strobes a single raw nibble (0x0-0xF) onto D4-D7 with RS held
+//low.
+func (b *I2C4BitBackend) WriteNibble(nibble byte) error {
+	return b.write4bits((nibble << 4) & 0xF0)
+}
+
+func (b *I2C4BitBackend) send(val, mode byte) error {
+	high := val & byte(0xF0)
+	low := (val << 4) & 0xF0
+
+	if err := b.write4bits(high | mode); err != nil {
+		return err
+	}
+
+	return b.write4bits(low | mode)
+}
+
+//WriteCommand sends a control byte split across two nibbles.
+func (b *I2C4BitBackend) WriteCommand(val byte) error {
+	return b.send(val, 0)
+}
+
+//WriteData sends a data byte split across two nibbles.
+func (b *I2C4BitBackend) WriteData(val byte) error {
+	return b.send(val, rs)
+}
+
+//SetBacklight turns the backlight line on the expander on or off, honoring
+//the backend's configured Polarity.
+func (b *I2C4BitBackend) SetBacklight(on bool) error {
+	if b.backlightPolarity == NegativePolarity {
+		on = !on
+	}
+
+	if on {
+		b.backLight = lcdBacklight
+	} else {
+		b.backLight = lcdNoBacklight
+	}
+
+	return b.expandWrite(0)
+}
+
+//FourBitMode always returns true; the PCF8574 only exposes enough lines for
+//a 4-bit wide bus.
+func (b *I2C4BitBackend) FourBitMode() bool {
+	return true
+}
+
+//WriteDataBatch pushes a whole run of data bytes to the HD44780 in a single
+//I2C transaction, rather than one WriteByte call per nibble plus a sleep.
+func (b *I2C4BitBackend) WriteDataBatch(data []byte) error {
+	return b.flush(data, rs)
+}
+
+// flush builds an EN-high and an EN-low expander state for each nibble of
+// data, in one shot, then streams them over I2C and sleeps once for the
+// cumulative enable-pulse settle time instead of once per nibble. Unlike
+// send, it writes no separate set-up state ahead of each EN-high state.
+func (b *I2C4BitBackend) flush(data []byte, mode byte) error {
+	states := make([]byte, 0, len(data)*4)
+
+	appendNibble := func(nibble byte) {
+		val := (nibble & 0xF0) | mode | b.backLight
+		states = append(states, val|en, val&^en)
+	}
+
+	for _, v := range data {
+		appendNibble(v)
+		appendNibble(v << 4)
+	}
+
+	if _, err := b.connection.Write(states); err != nil {
+		return err
+	}
+
+	time.Sleep(time.Duration(len(data)*2) * nibbleSettle)
+	return nil
+}
+
+//Busy reads the HD44780 busy flag (DB7) back over the PCF8574 by asserting
+//RW and tri-stating the upper nibble, then pulsing the enable line.
+func (b *I2C4BitBackend) Busy() (bool, error) {
+	read := rw | 0xF0 | b.backLight
+
+	if err := b.write(read); err != nil {
+		return false, err
+	}
+
+	if err := b.write(read | en); err != nil {
+		return false, err
+	}
+	time.Sleep(time.Microsecond)
+
+	val, err := b.connection.ReadByte()
+	if err != nil {
+		return false, err
+	}
+
+	if err := b.write(read); err != nil {
+		return false, err
+	}
+
+	// Clock the low nibble through too, to leave the bus in a known state;
+	// its value isn't meaningful for the busy flag.
+	if err := b.write(read | en); err != nil {
+		return false, err
+	}
+	time.Sleep(time.Microsecond)
+
+	if err := b.write(read); err != nil {
+		return false, err
+	}
+
+	return val&0x80 != 0, nil
+}
@@ -0,0 +1,143 @@
+package gobotlcd
+
+import "testing"
+
+// fakeI2CConnection is a minimal i2c.Connection used to unit test
+// I2C4BitBackend logic, such as backlight Polarity, without a real PCF8574
+// on the bus.
+type fakeI2CConnection struct {
+	written  []byte
+	batches  [][]byte
+	readByte byte
+}
+
+func (f *fakeI2CConnection) Read(p []byte) (int, error) { return 0, nil }
+
+func (f *fakeI2CConnection) Write(p []byte) (int, error) {
+	f.batches = append(f.batches, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func (f *fakeI2CConnection) Close() error { return nil }
+
+func (f *fakeI2CConnection) ReadByte() (byte, error) { return f.readByte, nil }
+func (f *fakeI2CConnection) ReadByteData(reg uint8) (uint8, error)    { return 0, nil }
+func (f *fakeI2CConnection) ReadWordData(reg uint8) (uint16, error)   { return 0, nil }
+func (f *fakeI2CConnection) WriteByteData(reg uint8, val uint8) error { return nil }
+func (f *fakeI2CConnection) WriteWordData(reg uint8, val uint16) error {
+	return nil
+}
+func (f *fakeI2CConnection) WriteBlockData(reg uint8, b []byte) error { return nil }
+
+func (f *fakeI2CConnection) WriteByte(val byte) error {
+	f.written = append(f.written, val)
+	return nil
+}
+
+func TestI2C4BitBackendWriteNibbleStrobesOnce(t *testing.T) {
+	conn := &fakeI2CConnection{}
+	backend := &I2C4BitBackend{connection: conn}
+
+	if err := backend.WriteNibble(0x03); err != nil {
+		t.Fatalf("WriteNibble: %v", err)
+	}
+
+	// A single nibble strobe sets the nibble, then pulses EN high and low
+	// once. A second nibble-and-pulse sequence here would mean WriteNibble
+	// silently sent a spurious second nibble, as lcd.command would.
+	want := []byte{0x30, 0x30 | en, 0x30}
+	if string(conn.written) != string(want) {
+		t.Errorf("WriteNibble(0x03) wrote %#v, want %#v", conn.written, want)
+	}
+}
+
+func TestI2C4BitBackendFlushWritesExpandedNibbleSequence(t *testing.T) {
+	conn := &fakeI2CConnection{}
+	backend := &I2C4BitBackend{connection: conn, backLight: lcdBacklight}
+
+	if err := backend.WriteDataBatch([]byte{0x41}); err != nil {
+		t.Fatalf("WriteDataBatch: %v", err)
+	}
+
+	if len(conn.batches) != 1 {
+		t.Fatalf("connection.Write called %d times, want 1 (one batched transaction)", len(conn.batches))
+	}
+
+	// data byte 0x41, rs=0x01, backLight=lcdBacklight(0x08): high nibble
+	// 0x40, low nibble (shifted up) 0x10, each expanded to an EN-high then
+	// EN-low state.
+	want := []byte{0x40 | rs | lcdBacklight | en, 0x40 | rs | lcdBacklight, 0x10 | rs | lcdBacklight | en, 0x10 | rs | lcdBacklight}
+	if got := conn.batches[0]; string(got) != string(want) {
+		t.Errorf("flush wrote %#v, want %#v", got, want)
+	}
+}
+
+func TestI2C4BitBackendBusyTrueReadsDB7Set(t *testing.T) {
+	conn := &fakeI2CConnection{readByte: 0x80}
+	backend := &I2C4BitBackend{connection: conn}
+
+	busy, err := backend.Busy()
+	if err != nil {
+		t.Fatalf("Busy: %v", err)
+	}
+	if !busy {
+		t.Error("Busy() = false, want true when DB7 is set")
+	}
+
+	read := rw | 0xF0
+	want := []byte{read, read | en, read, read | en, read}
+	if string(conn.written) != string(want) {
+		t.Errorf("Busy RW/EN sequence = %#v, want %#v", conn.written, want)
+	}
+}
+
+func TestI2C4BitBackendBusyFalseReadsDB7Clear(t *testing.T) {
+	conn := &fakeI2CConnection{readByte: 0x00}
+	backend := &I2C4BitBackend{connection: conn}
+
+	busy, err := backend.Busy()
+	if err != nil {
+		t.Fatalf("Busy: %v", err)
+	}
+	if busy {
+		t.Error("Busy() = true, want false when DB7 is clear")
+	}
+}
+
+func TestSetBacklightPositivePolarity(t *testing.T) {
+	conn := &fakeI2CConnection{}
+	backend := &I2C4BitBackend{connection: conn}
+
+	if err := backend.SetBacklight(true); err != nil {
+		t.Fatalf("SetBacklight(true): %v", err)
+	}
+	if got := conn.written[len(conn.written)-1]; got&lcdBacklight == 0 {
+		t.Errorf("SetBacklight(true) wrote %#x, want backlight bit set", got)
+	}
+
+	if err := backend.SetBacklight(false); err != nil {
+		t.Fatalf("SetBacklight(false): %v", err)
+	}
+	if got := conn.written[len(conn.written)-1]; got&lcdBacklight != 0 {
+		t.Errorf("SetBacklight(false) wrote %#x, want backlight bit clear", got)
+	}
+}
+
+func TestSetBacklightNegativePolarity(t *testing.T) {
+	conn := &fakeI2CConnection{}
+	backend := &I2C4BitBackend{connection: conn, backlightPolarity: NegativePolarity}
+
+	if err := backend.SetBacklight(true); err != nil {
+		t.Fatalf("SetBacklight(true): %v", err)
+	}
+	if got := conn.written[len(conn.written)-1]; got&lcdBacklight != 0 {
+		t.Errorf("SetBacklight(true) under NegativePolarity wrote %#x, want backlight bit clear", got)
+	}
+
+	if err := backend.SetBacklight(false); err != nil {
+		t.Fatalf("SetBacklight(false): %v", err)
+	}
+	if got := conn.written[len(conn.written)-1]; got&lcdBacklight == 0 {
+		t.Errorf("SetBacklight(false) under NegativePolarity wrote %#x, want backlight bit set", got)
+	}
+}
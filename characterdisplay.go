@@ -0,0 +1,183 @@
+package gobotlcd
+
+//CharacterDisplay wraps a *GobotLCD and maintains its own software cursor,
+//adding text helpers that wrap at the end of a row and a shadow buffer so
+//repeated refreshes only resend the cells that actually changed. Modeled on
+//how embd and the Linux charlcd driver layer a character-oriented API over
+//the raw HD44780 core.
+type CharacterDisplay struct {
+	lcd    *GobotLCD
+	cols   byte
+	rows   byte
+	col    byte
+	row    byte
+	shadow [][]byte
+}
+
+//NewCharacterDisplay wraps lcd with software cursor tracking and a shadow
+//buffer sized to lcd's row and column count.
+func NewCharacterDisplay(lcd *GobotLCD) *CharacterDisplay {
+	shadow := make([][]byte, lcd.rows)
+	for r := range shadow {
+		line := make([]byte, lcd.cols)
+		for c := range line {
+			line[c] = ' '
+		}
+		shadow[r] = line
+	}
+
+	return &CharacterDisplay{
+		lcd:    lcd,
+		cols:   lcd.cols,
+		rows:   lcd.rows,
+		shadow: shadow,
+	}
+}
+
+// newRow moves the software cursor to column 0 of the next row, wrapping
+// back to row 0, and repositions the hardware cursor to match.
+func (cd *CharacterDisplay) newRow() error {
+	cd.col = 0
+	cd.row++
+	if cd.row >= cd.rows {
+		cd.row = 0
+	}
+
+	return cd.lcd.SetCursor(cd.col, cd.row)
+}
+
+//Print writes s starting at the current cursor position, automatically
+//wrapping to column 0 of the next row once the current row fills up. Each
+//run up to the next wrap point is sent as a single Write call, so backends
+//implementing BatchWriter can push it in one transaction.
+func (cd *CharacterDisplay) Print(s string) error {
+	for len(s) > 0 {
+		if cd.col >= cd.cols {
+			if err := cd.newRow(); err != nil {
+				return err
+			}
+		}
+
+		n := int(cd.cols - cd.col)
+		if n > len(s) {
+			n = len(s)
+		}
+
+		if _, err := cd.lcd.Write([]byte(s[:n])); err != nil {
+			return err
+		}
+
+		cd.col += byte(n)
+		s = s[n:]
+	}
+
+	return nil
+}
+
+//Println writes s, pads the remainder of the row with spaces in a single
+//Write call, and moves to column 0 of the next row.
+func (cd *CharacterDisplay) Println(s string) error {
+	if err := cd.Print(s); err != nil {
+		return err
+	}
+
+	if pad := cd.cols - cd.col; pad > 0 {
+		spaces := make([]byte, pad)
+		for i := range spaces {
+			spaces[i] = ' '
+		}
+
+		if _, err := cd.lcd.Write(spaces); err != nil {
+			return err
+		}
+
+		cd.col = cd.cols
+	}
+
+	return cd.newRow()
+}
+
+//Message writes s, treating '\n' as a newline that moves to column 0 of the
+//next row instead of printing a character. Each run up to the next '\n' or
+//wrap point is sent as a single Write call.
+func (cd *CharacterDisplay) Message(s string) error {
+	i := 0
+
+	for i < len(s) {
+		if s[i] == '\n' {
+			if err := cd.newRow(); err != nil {
+				return err
+			}
+			i++
+			continue
+		}
+
+		if cd.col >= cd.cols {
+			if err := cd.newRow(); err != nil {
+				return err
+			}
+		}
+
+		start := i
+		width := int(cd.cols - cd.col)
+		for i < len(s) && i-start < width && s[i] != '\n' {
+			i++
+		}
+
+		if _, err := cd.lcd.Write([]byte(s[start:i])); err != nil {
+			return err
+		}
+
+		cd.col += byte(i - start)
+	}
+
+	return nil
+}
+
+//UpdateLine writes a fixed-width line to row, diffing against a shadow
+//buffer so that only the cells that actually changed are re-sent over the
+//wire. s is padded with spaces or truncated to the display's column count.
+//row is clamped to the display's row count, matching GobotLCD.SetCursor.
+func (cd *CharacterDisplay) UpdateLine(row byte, s string) error {
+	if row > cd.rows-1 {
+		row = cd.rows - 1
+	}
+
+	line := make([]byte, cd.cols)
+	for i := range line {
+		if i < len(s) {
+			line[i] = s[i]
+		} else {
+			line[i] = ' '
+		}
+	}
+
+	shadow := cd.shadow[row]
+	var i byte
+
+	for i < cd.cols {
+		if shadow[i] == line[i] {
+			i++
+			continue
+		}
+
+		start := i
+		for i < cd.cols && shadow[i] != line[i] {
+			shadow[i] = line[i]
+			i++
+		}
+
+		if err := cd.lcd.SetCursor(start, row); err != nil {
+			return err
+		}
+
+		if _, err := cd.lcd.Write(line[start:i]); err != nil {
+			return err
+		}
+	}
+
+	cd.row = row
+	cd.col = i
+
+	return nil
+}
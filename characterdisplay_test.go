@@ -0,0 +1,135 @@
+package gobotlcd
+
+import "testing"
+
+func TestCharacterDisplayPrintWraps(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.Print("abcdef"); err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+
+	if string(backend.data) != "abcdef" {
+		t.Errorf("data = %q, want %q", backend.data, "abcdef")
+	}
+
+	if cd.row != 1 || cd.col != 2 {
+		t.Errorf("cursor = (row %d, col %d), want (1, 2)", cd.row, cd.col)
+	}
+}
+
+func TestCharacterDisplayPrintlnPadsAndAdvances(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.Println("ab"); err != nil {
+		t.Fatalf("Println: %v", err)
+	}
+
+	if string(backend.data) != "ab  " {
+		t.Errorf("data = %q, want %q", backend.data, "ab  ")
+	}
+
+	if cd.row != 1 || cd.col != 0 {
+		t.Errorf("cursor = (row %d, col %d), want (1, 0)", cd.row, cd.col)
+	}
+}
+
+func TestCharacterDisplayMessageTreatsNewlineSpecially(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.Message("ab\ncd"); err != nil {
+		t.Fatalf("Message: %v", err)
+	}
+
+	if string(backend.data) != "abcd" {
+		t.Errorf("data = %q, want %q", backend.data, "abcd")
+	}
+
+	if cd.row != 1 || cd.col != 2 {
+		t.Errorf("cursor = (row %d, col %d), want (1, 2)", cd.row, cd.col)
+	}
+}
+
+func TestCharacterDisplayUpdateLineOnlySendsChangedCells(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.UpdateLine(0, "abcd"); err != nil {
+		t.Fatalf("UpdateLine: %v", err)
+	}
+	backend.data = nil
+
+	if err := cd.UpdateLine(0, "abXd"); err != nil {
+		t.Fatalf("UpdateLine: %v", err)
+	}
+
+	if string(backend.data) != "X" {
+		t.Errorf("data = %q, want only the changed cell %q", backend.data, "X")
+	}
+}
+
+func TestCharacterDisplayPrintBatchesEachRowRun(t *testing.T) {
+	backend := newFakeBatchBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.Print("abcdef"); err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+
+	// "abcd" fills row 0 in one run, then "ef" is a second run on row 1 -
+	// two Write calls total, not six.
+	if backend.batchCalls != 2 {
+		t.Errorf("Print issued %d Write calls, want 2 (one per row run)", backend.batchCalls)
+	}
+	if string(backend.data) != "abcdef" {
+		t.Errorf("data = %q, want %q", backend.data, "abcdef")
+	}
+}
+
+func TestCharacterDisplayPrintlnBatchesTextAndPadding(t *testing.T) {
+	backend := newFakeBatchBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.Println("ab"); err != nil {
+		t.Fatalf("Println: %v", err)
+	}
+
+	// one Write for "ab", one for the padding - not four single-byte calls.
+	if backend.batchCalls != 2 {
+		t.Errorf("Println issued %d Write calls, want 2 (text + padding)", backend.batchCalls)
+	}
+}
+
+func TestCharacterDisplayMessageBatchesRunsBetweenNewlines(t *testing.T) {
+	backend := newFakeBatchBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.Message("ab\ncd"); err != nil {
+		t.Fatalf("Message: %v", err)
+	}
+
+	// one Write for "ab", one for "cd" - the '\n' itself issues no Write.
+	if backend.batchCalls != 2 {
+		t.Errorf("Message issued %d Write calls, want 2 (one run per line)", backend.batchCalls)
+	}
+}
+
+func TestCharacterDisplayUpdateLineClampsRow(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 4, 2, DotSize5x8)
+	cd := NewCharacterDisplay(lcd)
+
+	if err := cd.UpdateLine(5, "ab"); err != nil {
+		t.Fatalf("UpdateLine with out-of-range row: %v", err)
+	}
+}
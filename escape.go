@@ -0,0 +1,172 @@
+package gobotlcd
+
+// escape-sequence states for the small VT-like grammar WithEscapeSequences
+// enables on Write.
+const (
+	escStateNone = iota
+	escStateEsc
+	escStateCSI
+	escStateCustomReg
+	escStateCustomData
+)
+
+// escapeState buffers a partial escape sequence across Write calls, so
+// fmt.Fprintf(lcd, ...) can split a sequence across several small writes.
+type escapeState struct {
+	state     int
+	params    []int
+	hasDigit  bool
+	customReg byte
+	customBuf [8]byte
+	customIdx int
+}
+
+//WithEscapeSequences enables a small VT-like escape grammar in Write:
+//  \f            Clear
+//  \r            move to column 0 of the current row
+//  \n            advance to the next row, wrapping back to row 0
+//  \b            move the cursor left one position
+//  ESC [ r ; c H SetCursor(c-1, r-1)
+//  ESC [ 2 J     Clear
+//  ESC [ K       erase to the end of the current line
+//  ESC [ L x y   RegisterCharacter(x, y), where y is the following 8 raw
+//                CGRAM bytes
+//This lets callers drive the LCD as a plain io.Writer instead of calling
+//the positional methods directly.
+func WithEscapeSequences() Option {
+	return func(lcd *GobotLCD) {
+		lcd.escapeSeq = true
+	}
+}
+
+func (lcd *GobotLCD) writeEscaped(b byte) error {
+	switch lcd.esc.state {
+	case escStateEsc:
+		return lcd.handleEsc(b)
+	case escStateCSI:
+		return lcd.handleCSI(b)
+	case escStateCustomReg:
+		lcd.esc.customReg = b
+		lcd.esc.customIdx = 0
+		lcd.esc.state = escStateCustomData
+		return nil
+	case escStateCustomData:
+		return lcd.handleCustomData(b)
+	}
+
+	switch b {
+	case 0x1B:
+		lcd.esc = escapeState{state: escStateEsc}
+		return nil
+	case '\f':
+		lcd.curCol, lcd.curRow = 0, 0
+		return lcd.Clear()
+	case '\r':
+		lcd.curCol = 0
+		return lcd.SetCursor(lcd.curCol, lcd.curRow)
+	case '\n':
+		lcd.curRow++
+		if lcd.curRow >= lcd.rows {
+			lcd.curRow = 0
+		}
+		return lcd.SetCursor(lcd.curCol, lcd.curRow)
+	case '\b':
+		if lcd.curCol > 0 {
+			lcd.curCol--
+		}
+		return lcd.SetCursor(lcd.curCol, lcd.curRow)
+	default:
+		if err := lcd.backend.WriteData(b); err != nil {
+			return err
+		}
+
+		lcd.curCol++
+		if lcd.curCol >= lcd.cols {
+			lcd.curCol = 0
+		}
+
+		return nil
+	}
+}
+
+func (lcd *GobotLCD) handleEsc(b byte) error {
+	if b == '[' {
+		lcd.esc = escapeState{state: escStateCSI}
+		return nil
+	}
+
+	// Unrecognized escape sequence; drop back to normal mode.
+	lcd.esc.state = escStateNone
+	return nil
+}
+
+func (lcd *GobotLCD) handleCSI(b byte) error {
+	switch {
+	case b >= '0' && b <= '9':
+		if !lcd.esc.hasDigit {
+			lcd.esc.params = append(lcd.esc.params, 0)
+			lcd.esc.hasDigit = true
+		}
+
+		last := len(lcd.esc.params) - 1
+		lcd.esc.params[last] = lcd.esc.params[last]*10 + int(b-'0')
+		return nil
+	case b == ';':
+		lcd.esc.hasDigit = false
+		return nil
+	case b == 'H':
+		row, col := 1, 1
+		if len(lcd.esc.params) > 0 {
+			row = lcd.esc.params[0]
+		}
+		if len(lcd.esc.params) > 1 {
+			col = lcd.esc.params[1]
+		}
+
+		lcd.esc.state = escStateNone
+		lcd.curRow = byte(row - 1)
+		lcd.curCol = byte(col - 1)
+		return lcd.SetCursor(lcd.curCol, lcd.curRow)
+	case b == 'J':
+		lcd.esc.state = escStateNone
+		lcd.curCol, lcd.curRow = 0, 0
+		return lcd.Clear()
+	case b == 'K':
+		lcd.esc.state = escStateNone
+		return lcd.eraseToEndOfLine()
+	case b == 'L':
+		lcd.esc.state = escStateCustomReg
+		return nil
+	default:
+		// Unrecognized final byte; drop back to normal mode.
+		lcd.esc.state = escStateNone
+		return nil
+	}
+}
+
+func (lcd *GobotLCD) handleCustomData(b byte) error {
+	lcd.esc.customBuf[lcd.esc.customIdx] = b
+	lcd.esc.customIdx++
+
+	if lcd.esc.customIdx < len(lcd.esc.customBuf) {
+		return nil
+	}
+
+	lcd.esc.state = escStateNone
+	cchar := NewCharacter(lcd.esc.customBuf)
+	return lcd.RegisterCharacter(lcd.esc.customReg, cchar)
+}
+
+// eraseToEndOfLine writes spaces from the current cursor position to the
+// end of the row, then restores the cursor.
+func (lcd *GobotLCD) eraseToEndOfLine() error {
+	col, row := lcd.curCol, lcd.curRow
+
+	for c := col; c < lcd.cols; c++ {
+		if err := lcd.backend.WriteData(' '); err != nil {
+			return err
+		}
+	}
+
+	return lcd.SetCursor(col, row)
+}
@@ -0,0 +1,105 @@
+package gobotlcd
+
+import "testing"
+
+func TestWriteEscapeCursorPosition(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 20, 4, DotSize5x8, WithEscapeSequences())
+
+	if _, err := lcd.Write([]byte("\x1b[2;5H")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := lcdSetDDRAMAddr | (4 + lcd.rowAddress[1])
+	got := backend.commands[len(backend.commands)-1]
+	if got != want {
+		t.Errorf("ESC[2;5H command = %#x, want %#x", got, want)
+	}
+}
+
+func TestWriteEscapeSplitAcrossWrites(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 20, 4, DotSize5x8, WithEscapeSequences())
+
+	for _, chunk := range []string{"\x1b", "[2", ";5H"} {
+		if _, err := lcd.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write(%q): %v", chunk, err)
+		}
+	}
+
+	want := lcdSetDDRAMAddr | (4 + lcd.rowAddress[1])
+	got := backend.commands[len(backend.commands)-1]
+	if got != want {
+		t.Errorf("ESC[2;5H split across writes command = %#x, want %#x", got, want)
+	}
+}
+
+func TestWriteEscapeClear(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 20, 4, DotSize5x8, WithEscapeSequences())
+
+	if _, err := lcd.Write([]byte("\x1b[2J")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	found := false
+	for _, c := range backend.commands {
+		if c == lcdClearDisplay {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("ESC[2J did not issue Clear, commands = %#v", backend.commands)
+	}
+}
+
+func TestWriteEscapeEraseToEndOfLine(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 4, 1, DotSize5x8, WithEscapeSequences())
+
+	if _, err := lcd.Write([]byte("ab\x1b[K")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := "ab  "
+	if string(backend.data) != want {
+		t.Errorf("data = %q, want %q", backend.data, want)
+	}
+}
+
+func TestWriteEscapeRegisterCharacter(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 20, 4, DotSize5x8, WithEscapeSequences())
+
+	charmap := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	seq := append([]byte("\x1b[L"), 3)
+	seq = append(seq, charmap[:]...)
+
+	if _, err := lcd.Write(seq); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	wantCmd := lcdSetCGramAddr | (byte(3) << 3)
+	if got := backend.commands[len(backend.commands)-1]; got != wantCmd {
+		t.Errorf("last command = %#x, want %#x", got, wantCmd)
+	}
+
+	if string(backend.data[len(backend.data)-8:]) != string(charmap[:]) {
+		t.Errorf("data tail = %v, want %v", backend.data[len(backend.data)-8:], charmap)
+	}
+}
+
+func TestWriteEscapeNewlineWrapsRow(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 20, 2, DotSize5x8, WithEscapeSequences())
+
+	if _, err := lcd.Write([]byte("\n\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := lcdSetDDRAMAddr | lcd.rowAddress[0]
+	got := backend.commands[len(backend.commands)-1]
+	if got != want {
+		t.Errorf("second \\n command = %#x, want %#x (wrap back to row 0)", got, want)
+	}
+}
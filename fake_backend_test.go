@@ -0,0 +1,58 @@
+package gobotlcd
+
+import "gobot.io/x/gobot"
+
+// fakeBackend is a hardware-independent Backend used to unit test the
+// pure-Go logic layered on top of it (cursor math, escape sequences,
+// CharacterDisplay wrapping) without touching I2C or GPIO.
+type fakeBackend struct {
+	commands    []byte
+	data        []byte
+	backlightOn bool
+	fourBit     bool
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{fourBit: true}
+}
+
+func (f *fakeBackend) Start() error { return nil }
+
+func (f *fakeBackend) Connection() gobot.Connection { return nil }
+
+func (f *fakeBackend) WriteCommand(b byte) error {
+	f.commands = append(f.commands, b)
+	return nil
+}
+
+func (f *fakeBackend) WriteData(b byte) error {
+	f.data = append(f.data, b)
+	return nil
+}
+
+func (f *fakeBackend) SetBacklight(on bool) error {
+	f.backlightOn = on
+	return nil
+}
+
+func (f *fakeBackend) Pulse() error { return nil }
+
+func (f *fakeBackend) FourBitMode() bool { return f.fourBit }
+
+// fakeBatchBackend additionally implements BatchWriter, so tests can count
+// how many Write transactions a higher-level call issued instead of just
+// inspecting the bytes that ended up on the wire.
+type fakeBatchBackend struct {
+	*fakeBackend
+	batchCalls int
+}
+
+func newFakeBatchBackend() *fakeBatchBackend {
+	return &fakeBatchBackend{fakeBackend: newFakeBackend()}
+}
+
+func (f *fakeBatchBackend) WriteDataBatch(b []byte) error {
+	f.batchCalls++
+	f.data = append(f.data, b...)
+	return nil
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
-	"gobot.io/x/gobot/drivers/i2c"
 )
 
 const (
@@ -52,6 +51,7 @@ const (
 	lcdNoBacklight byte = 0x00
 
 	en byte = 0x04 // Enable bit
+	rw byte = 0x02 // Read/Write bit
 	rs byte = 0x01 // Register select bit
 )
 
@@ -65,7 +65,27 @@ const (
 	DotSize5x8 DotSize = lcd5x8Dots
 )
 
-//GobotLCD controls a Liquid Crystal LCD with an I2C connection.
+//RowAddress holds the DDRAM address that each of up to four rows starts at.
+//HD44780 controllers wire their rows to different DDRAM offsets depending
+//on the physical column count of the display.
+type RowAddress [4]byte
+
+var (
+	//RowAddress16Col is the DDRAM row map used by 16x2 and 16x4 displays.
+	RowAddress16Col = RowAddress{0x00, 0x40, 0x10, 0x50}
+	//RowAddress20Col is the DDRAM row map used by 20x2 and 20x4 displays.
+	RowAddress20Col = RowAddress{0x00, 0x40, 0x14, 0x54}
+)
+
+//WithRowAddresses selects the DDRAM row map used by SetCursor. Defaults to
+//RowAddress20Col if not given, for backward compatibility.
+func WithRowAddresses(rowAddress RowAddress) Option {
+	return func(lcd *GobotLCD) {
+		lcd.rowAddress = rowAddress
+	}
+}
+
+//GobotLCD controls a Liquid Crystal LCD through a pluggable Backend.
 type GobotLCD struct {
 	name       string
 	displFn    byte
@@ -73,23 +93,78 @@ type GobotLCD struct {
 	displMode  byte
 	cols       byte
 	rows       byte
-	backLight  byte
-	connector  i2c.Connector
-	connection i2c.Connection
-	i2c.Config
+	rowAddress RowAddress
+	backend    Backend
+	escapeSeq  bool
+	esc        escapeState
+	curCol     byte
+	curRow     byte
+	busyWait   bool
+}
+
+//WithBusyWait makes Clear and Home poll the HD44780 busy flag through the
+//backend's Busy method, when the backend implements BusyWaiter, instead of
+//sleeping a fixed settle time. Backends that don't implement BusyWaiter fall
+//back to the fixed sleep.
+func WithBusyWait() Option {
+	return func(lcd *GobotLCD) {
+		lcd.busyWait = true
+	}
+}
+
+// busyWaitTimeout bounds how long settle will poll the busy flag before
+// giving up, so a stuck bus or miswired RW line can't hang the caller
+// forever.
+const busyWaitTimeout = 100 * time.Millisecond
+
+// settle waits for the HD44780 to finish its current instruction, either by
+// polling the busy flag (if WithBusyWait was given and the backend supports
+// it) or by sleeping a fixed, conservative duration.
+func (lcd *GobotLCD) settle(fallback time.Duration) error {
+	if lcd.busyWait {
+		if bw, ok := lcd.backend.(BusyWaiter); ok {
+			deadline := time.Now().Add(busyWaitTimeout)
+
+			for {
+				busy, err := bw.Busy()
+				if err != nil {
+					return err
+				}
+
+				if !busy {
+					return nil
+				}
+
+				if time.Now().After(deadline) {
+					return fmt.Errorf("gobotlcd: timed out waiting for busy flag to clear")
+				}
+			}
+		}
+	}
+
+	time.Sleep(fallback)
+	return nil
 }
 
-//New connects to an LCD with the given I2C connection, the given row
-//and column size, and the given dot size.
-func New(a i2c.Connector, cols, rows byte, dotSize DotSize, options ...func(i2c.Config)) *GobotLCD {
+//Option configures a GobotLCD created by New.
+type Option func(*GobotLCD)
+
+//New creates an LCD driver for the given Backend, the given row and column
+//size, and the given dot size. Use NewI2C4BitBackend, NewGPIO4BitBackend or
+//NewGPIO8BitBackend to build the backend for how the display is wired.
+func New(backend Backend, cols, rows byte, dotSize DotSize, options ...Option) *GobotLCD {
+	displFn := byte(lcd4BitMode)
+	if !backend.FourBitMode() {
+		displFn = lcd8BitMode
+	}
+
 	ret := &GobotLCD{
-		name:      gobot.DefaultName("LiquidCrystalLCD"),
-		displFn:   lcd4BitMode | lcd1Line | lcd5x8Dots,
-		backLight: lcdNoBacklight,
-		cols:      cols,
-		rows:      rows,
-		connector: a,
-		Config:    i2c.NewConfig(),
+		name:       gobot.DefaultName("LiquidCrystalLCD"),
+		displFn:    displFn | lcd1Line | lcd5x8Dots,
+		cols:       cols,
+		rows:       rows,
+		rowAddress: RowAddress20Col,
+		backend:    backend,
 	}
 
 	if ret.rows > 1 {
@@ -121,11 +196,15 @@ func (lcd *GobotLCD) Name() string {
 
 // Connection returns the Connection associated with the Driver
 func (lcd *GobotLCD) Connection() gobot.Connection {
-	return lcd.connection.(gobot.Connection)
+	return lcd.backend.Connection()
 }
 
 // Start initiates the Driver
 func (lcd *GobotLCD) Start() (err error) {
+	if err = lcd.backend.Start(); err != nil {
+		return err
+	}
+
 	return lcd.init()
 }
 
@@ -148,70 +227,18 @@ func (lcd *GobotLCD) Halt() (err error) {
 
 // end Gobot driver interface methods
 
-func (lcd *GobotLCD) write(val byte) error {
-	return lcd.connection.WriteByte(val)
-}
-
-func (lcd *GobotLCD) expandWrite(val byte) error {
-	return lcd.write(val | lcd.backLight)
-}
-
-func (lcd *GobotLCD) pulseEnable(val byte) error {
-	if err := lcd.expandWrite(val | en); err != nil {
-		return err
-	}
-	time.Sleep(time.Microsecond)
-
-	if err := lcd.expandWrite(val & ^en); err != nil {
-		return err
-	}
-	time.Sleep(50 * time.Microsecond)
-
-	return nil
-}
-
-func (lcd *GobotLCD) write4bits(val byte) error {
-	if err := lcd.expandWrite(val); err != nil {
-		return err
-	}
-
-	return lcd.pulseEnable(val)
-}
-
-func (lcd *GobotLCD) send(val, mode byte) error {
-	high := val & byte(0xF0)
-	low := (val << 4) & 0xF0
-
-	if err := lcd.write4bits(high | mode); err != nil {
-		return err
-	}
-
-	return lcd.write4bits(low | mode)
-}
-
 func (lcd *GobotLCD) command(val byte) error {
-	return lcd.send(val, 0)
+	return lcd.backend.WriteCommand(val)
 }
 
 func (lcd *GobotLCD) init() (err error) {
-	bus := lcd.GetBusOrDefault(1)
-	address := lcd.GetAddressOrDefault(0x27)
-
-	lcd.connection, err = lcd.connector.GetConnection(address, bus)
-	if err != nil {
-		return err
-	}
-
-	// LCD requires 40 ms after power-on before receiving commands
-	time.Sleep(50 * time.Millisecond)
-
-	if err = lcd.write(lcd.backLight); err != nil {
-		return err
+	if lcd.backend.FourBitMode() {
+		err = lcd.init4BitMode()
+	} else {
+		err = lcd.init8BitMode()
 	}
 
-	time.Sleep(time.Second)
-
-	if err = lcd.init4BitMode(); err != nil {
+	if err != nil {
 		return err
 	}
 
@@ -238,23 +265,49 @@ func (lcd *GobotLCD) init() (err error) {
 	return lcd.Home()
 }
 
+// init4BitMode runs the HD44780's documented "init by instruction" reset
+// sequence for a 4-bit bus. At this point the controller is still assuming
+// 8-bit-style decoding, so each bootstrap step must be a single raw nibble
+// strobe, not the two-nibble split WriteCommand sends once the controller
+// is actually in 4-bit mode - hence the NibbleWriter rather than
+// lcd.command here.
 func (lcd *GobotLCD) init4BitMode() error {
-	if err := lcd.write4bits(0x03 << 4); err != nil {
+	nw, ok := lcd.backend.(NibbleWriter)
+	if !ok {
+		return fmt.Errorf("gobotlcd: backend does not implement NibbleWriter, required for 4-bit mode init")
+	}
+
+	if err := nw.WriteNibble(0x03); err != nil {
 		return err
 	}
 	time.Sleep(45 * time.Millisecond)
 
-	if err := lcd.write4bits(0x03 << 4); err != nil {
+	if err := nw.WriteNibble(0x03); err != nil {
 		return err
 	}
 	time.Sleep(45 * time.Millisecond)
 
-	if err := lcd.write4bits(0x03 << 4); err != nil {
+	if err := nw.WriteNibble(0x03); err != nil {
 		return err
 	}
 	time.Sleep(150 * time.Microsecond)
 
-	return lcd.write4bits(0x02 << 4)
+	return nw.WriteNibble(0x02)
+}
+
+// init8BitMode runs the HD44780's documented reset sequence for an 8-bit bus.
+func (lcd *GobotLCD) init8BitMode() error {
+	if err := lcd.command(lcdFunctionSet | lcd8BitMode); err != nil {
+		return err
+	}
+	time.Sleep(45 * time.Millisecond)
+
+	if err := lcd.command(lcdFunctionSet | lcd8BitMode); err != nil {
+		return err
+	}
+	time.Sleep(45 * time.Millisecond)
+
+	return lcd.command(lcdFunctionSet | lcd8BitMode)
 }
 
 //Clear wipes all text from the screen and positions the cursor at the top-left
@@ -263,8 +316,8 @@ func (lcd *GobotLCD) Clear() error {
 		return err
 	}
 
-	time.Sleep(2 * time.Millisecond)
-	return nil
+	lcd.curCol, lcd.curRow = 0, 0
+	return lcd.settle(2 * time.Millisecond)
 }
 
 //Home returns the cursor to the top-left
@@ -273,8 +326,8 @@ func (lcd *GobotLCD) Home() error {
 		return err
 	}
 
-	time.Sleep(2 * time.Millisecond)
-	return nil
+	lcd.curCol, lcd.curRow = 0, 0
+	return lcd.settle(2 * time.Millisecond)
 }
 
 //DisplayOn turns the text display on
@@ -291,14 +344,12 @@ func (lcd *GobotLCD) DisplayOff() error {
 
 //BacklightOn turns the lcd light on
 func (lcd *GobotLCD) BacklightOn() error {
-	lcd.backLight = lcdBacklight
-	return lcd.expandWrite(0)
+	return lcd.backend.SetBacklight(true)
 }
 
 //BacklightOff turns the lcd light off
 func (lcd *GobotLCD) BacklightOff() error {
-	lcd.backLight = lcdNoBacklight
-	return lcd.expandWrite(0)
+	return lcd.backend.SetBacklight(false)
 }
 
 //UnderlineOn turns on the underline cursor
@@ -363,8 +414,6 @@ func (lcd *GobotLCD) AutoScrollOff() error {
 
 //SetCursor positions the cursor at the specified row/column.
 func (lcd *GobotLCD) SetCursor(col, row byte) error {
-	var rowOffset = []byte{0, 0x40, 0x14, 0x54}
-
 	if row > lcd.rows-1 {
 		row = lcd.rows - 1
 	}
@@ -373,7 +422,9 @@ func (lcd *GobotLCD) SetCursor(col, row byte) error {
 		col = lcd.cols - 1
 	}
 
-	return lcd.command(lcdSetDDRAMAddr | (col + rowOffset[row]))
+	lcd.curCol, lcd.curRow = col, row
+
+	return lcd.command(lcdSetDDRAMAddr | (col + lcd.rowAddress[row]))
 }
 
 //RegisterCharacter registers a custom character to display on the lcd.
@@ -387,7 +438,7 @@ func (lcd *GobotLCD) RegisterCharacter(location byte, charmap *CustomCharacter)
 	}
 
 	for _, i := range charmap.CharMap {
-		if err := lcd.send(i, rs); err != nil {
+		if err := lcd.backend.WriteData(i); err != nil {
 			return err
 		}
 	}
@@ -398,11 +449,35 @@ func (lcd *GobotLCD) RegisterCharacter(location byte, charmap *CustomCharacter)
 }
 
 //Write satisfies the io.Writer interface so it can be used with fmt or the I/O of your choice.
+//If WithEscapeSequences was given to New, Write also interprets a small
+//VT-like escape grammar; see WithEscapeSequences for details.
 func (lcd *GobotLCD) Write(str []byte) (int, error) {
+	if !lcd.escapeSeq {
+		return lcd.writeRaw(str)
+	}
+
+	for i, b := range str {
+		if err := lcd.writeEscaped(b); err != nil {
+			return i, err
+		}
+	}
+
+	return len(str), nil
+}
+
+func (lcd *GobotLCD) writeRaw(str []byte) (int, error) {
+	if bw, ok := lcd.backend.(BatchWriter); ok {
+		if err := bw.WriteDataBatch(str); err != nil {
+			return 0, err
+		}
+
+		return len(str), nil
+	}
+
 	i := 0
 
 	for i < len(str) {
-		if err := lcd.send(str[i], rs); err != nil {
+		if err := lcd.backend.WriteData(str[i]); err != nil {
 			return i, err
 		}
 
@@ -424,8 +499,8 @@ func NewCharacter(charmap [8]byte) *CustomCharacter {
 }
 
 //String will return an ASCII value of the register.  Use this with Fprintf and pass in
-//your custom character, or call LiquidCrystalLCD.Write and pass in the register value.
-//  cchar := liquidcrystallcd.NewCharacter([8]byte{...})
+//your custom character, or call GobotLCD.Write and pass in the register value.
+//  cchar := gobotlcd.NewCharacter([8]byte{...})
 //  lcd.RegisterCharacter(0, cchar)
 //  lcd.Home()
 //  fmt.Fprintf(lcd, "This is a custom character: %v", cchar) // Use Fprintf...
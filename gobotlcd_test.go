@@ -0,0 +1,52 @@
+package gobotlcd
+
+import "testing"
+
+// fakeNibbleBackend extends fakeBackend with NibbleWriter support, so
+// init4BitMode's raw nibble strobes can be pinned down separately from any
+// full command bytes it might (incorrectly) send.
+type fakeNibbleBackend struct {
+	*fakeBackend
+	nibbles []byte
+}
+
+func newFakeNibbleBackend() *fakeNibbleBackend {
+	return &fakeNibbleBackend{fakeBackend: newFakeBackend()}
+}
+
+func (f *fakeNibbleBackend) WriteNibble(nibble byte) error {
+	f.nibbles = append(f.nibbles, nibble)
+	return nil
+}
+
+func TestInit4BitModeStrobesRawNibblesOnly(t *testing.T) {
+	backend := newFakeNibbleBackend()
+	lcd := New(backend, 16, 2, DotSize5x8)
+
+	if err := lcd.init4BitMode(); err != nil {
+		t.Fatalf("init4BitMode: %v", err)
+	}
+
+	want := []byte{0x03, 0x03, 0x03, 0x02}
+	if len(backend.nibbles) != len(want) {
+		t.Fatalf("nibbles = %#v, want %#v", backend.nibbles, want)
+	}
+	for i := range want {
+		if backend.nibbles[i] != want[i] {
+			t.Errorf("nibbles[%d] = %#x, want %#x", i, backend.nibbles[i], want[i])
+		}
+	}
+
+	if len(backend.commands) != 0 {
+		t.Errorf("init4BitMode issued %d full WriteCommand bytes, want 0: %#v", len(backend.commands), backend.commands)
+	}
+}
+
+func TestInit4BitModeRequiresNibbleWriter(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 16, 2, DotSize5x8)
+
+	if err := lcd.init4BitMode(); err == nil {
+		t.Error("init4BitMode with a backend lacking NibbleWriter: got nil error, want one")
+	}
+}
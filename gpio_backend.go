@@ -0,0 +1,259 @@
+package gobotlcd
+
+import (
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/sysfs"
+)
+
+//GPIO4BitBackend drives the HD44780 directly over four GPIO data lines
+//(D4-D7), the same wiring most breadboard HD44780 tutorials use.
+type GPIO4BitBackend struct {
+	rs, en         sysfs.DigitalPinner
+	d4, d5, d6, d7 sysfs.DigitalPinner
+	backlight      sysfs.DigitalPinner
+}
+
+//NewGPIO4BitBackend drives the HD44780 over the given register-select,
+//enable, data and backlight pins, wired in 4-bit mode. backlight may be nil
+//if the display's backlight isn't under GPIO control.
+func NewGPIO4BitBackend(rs, en, d4, d5, d6, d7, backlight sysfs.DigitalPinner) *GPIO4BitBackend {
+	return &GPIO4BitBackend{
+		rs:        rs,
+		en:        en,
+		d4:        d4,
+		d5:        d5,
+		d6:        d6,
+		d7:        d7,
+		backlight: backlight,
+	}
+}
+
+//Start waits for the HD44780 power-on period. GPIO pins are assumed to
+//already be exported by the caller.
+func (b *GPIO4BitBackend) Start() error {
+	time.Sleep(50 * time.Millisecond)
+	return nil
+}
+
+//Connection has no single connection to report for direct GPIO wiring.
+func (b *GPIO4BitBackend) Connection() gobot.Connection {
+	return nil
+}
+
+func (b *GPIO4BitBackend) writeNibble(nibble byte) error {
+	pins := [4]sysfs.DigitalPinner{b.d4, b.d5, b.d6, b.d7}
+
+	for i, pin := range pins {
+		bit := sysfs.LOW
+		if nibble&(1<<uint(i)) != 0 {
+			bit = sysfs.HIGH
+		}
+
+		if err := pin.Write(bit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//WriteNibble strobes a single raw nibble onto D4-D7 with RS held low.
+func (b *GPIO4BitBackend) WriteNibble(nibble byte) error {
+	if err := b.rs.Write(sysfs.LOW); err != nil {
+		return err
+	}
+
+	if err := b.writeNibble(nibble & 0x0F); err != nil {
+		return err
+	}
+
+	return b.Pulse()
+}
+
+//Pulse toggles the enable line once against whatever nibble is currently
+//held on D4-D7.
+func (b *GPIO4BitBackend) Pulse() error {
+	if err := b.en.Write(sysfs.HIGH); err != nil {
+		return err
+	}
+	time.Sleep(time.Microsecond)
+
+	if err := b.en.Write(sysfs.LOW); err != nil {
+		return err
+	}
+	time.Sleep(50 * time.Microsecond)
+
+	return nil
+}
+
+func (b *GPIO4BitBackend) send(val, mode byte) error {
+	rs := sysfs.LOW
+	if mode != 0 {
+		rs = sysfs.HIGH
+	}
+
+	if err := b.rs.Write(rs); err != nil {
+		return err
+	}
+
+	if err := b.writeNibble((val >> 4) & 0x0F); err != nil {
+		return err
+	}
+	if err := b.Pulse(); err != nil {
+		return err
+	}
+
+	if err := b.writeNibble(val & 0x0F); err != nil {
+		return err
+	}
+	return b.Pulse()
+}
+
+//WriteCommand sends a control byte split across two nibbles, with RS low.
+func (b *GPIO4BitBackend) WriteCommand(val byte) error {
+	return b.send(val, 0)
+}
+
+//WriteData sends a data byte split across two nibbles, with RS high.
+func (b *GPIO4BitBackend) WriteData(val byte) error {
+	return b.send(val, 1)
+}
+
+//SetBacklight drives the backlight pin, if one was given to
+//NewGPIO4BitBackend.
+func (b *GPIO4BitBackend) SetBacklight(on bool) error {
+	if b.backlight == nil {
+		return nil
+	}
+
+	if on {
+		return b.backlight.Write(sysfs.HIGH)
+	}
+
+	return b.backlight.Write(sysfs.LOW)
+}
+
+//FourBitMode always returns true; this backend only drives D4-D7.
+func (b *GPIO4BitBackend) FourBitMode() bool {
+	return true
+}
+
+//GPIO8BitBackend drives the HD44780 directly over eight GPIO data lines
+//(D0-D7).
+type GPIO8BitBackend struct {
+	rs, en                         sysfs.DigitalPinner
+	d0, d1, d2, d3, d4, d5, d6, d7 sysfs.DigitalPinner
+	backlight                      sysfs.DigitalPinner
+}
+
+//NewGPIO8BitBackend drives the HD44780 over the given register-select,
+//enable, data and backlight pins, wired in 8-bit mode. backlight may be nil
+//if the display's backlight isn't under GPIO control.
+func NewGPIO8BitBackend(rs, en, d0, d1, d2, d3, d4, d5, d6, d7, backlight sysfs.DigitalPinner) *GPIO8BitBackend {
+	return &GPIO8BitBackend{
+		rs:        rs,
+		en:        en,
+		d0:        d0,
+		d1:        d1,
+		d2:        d2,
+		d3:        d3,
+		d4:        d4,
+		d5:        d5,
+		d6:        d6,
+		d7:        d7,
+		backlight: backlight,
+	}
+}
+
+//Start waits for the HD44780 power-on period. GPIO pins are assumed to
+//already be exported by the caller.
+func (b *GPIO8BitBackend) Start() error {
+	time.Sleep(50 * time.Millisecond)
+	return nil
+}
+
+//Connection has no single connection to report for direct GPIO wiring.
+func (b *GPIO8BitBackend) Connection() gobot.Connection {
+	return nil
+}
+
+func (b *GPIO8BitBackend) writeByte(val byte) error {
+	pins := [8]sysfs.DigitalPinner{b.d0, b.d1, b.d2, b.d3, b.d4, b.d5, b.d6, b.d7}
+
+	for i, pin := range pins {
+		bit := sysfs.LOW
+		if val&(1<<uint(i)) != 0 {
+			bit = sysfs.HIGH
+		}
+
+		if err := pin.Write(bit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//Pulse toggles the enable line once against whatever byte is currently held
+//on D0-D7.
+func (b *GPIO8BitBackend) Pulse() error {
+	if err := b.en.Write(sysfs.HIGH); err != nil {
+		return err
+	}
+	time.Sleep(time.Microsecond)
+
+	if err := b.en.Write(sysfs.LOW); err != nil {
+		return err
+	}
+	time.Sleep(50 * time.Microsecond)
+
+	return nil
+}
+
+func (b *GPIO8BitBackend) send(val, mode byte) error {
+	rs := sysfs.LOW
+	if mode != 0 {
+		rs = sysfs.HIGH
+	}
+
+	if err := b.rs.Write(rs); err != nil {
+		return err
+	}
+
+	if err := b.writeByte(val); err != nil {
+		return err
+	}
+
+	return b.Pulse()
+}
+
+//WriteCommand sends a control byte in a single 8-bit transfer, with RS low.
+func (b *GPIO8BitBackend) WriteCommand(val byte) error {
+	return b.send(val, 0)
+}
+
+//WriteData sends a data byte in a single 8-bit transfer, with RS high.
+func (b *GPIO8BitBackend) WriteData(val byte) error {
+	return b.send(val, 1)
+}
+
+//SetBacklight drives the backlight pin, if one was given to
+//NewGPIO8BitBackend.
+func (b *GPIO8BitBackend) SetBacklight(on bool) error {
+	if b.backlight == nil {
+		return nil
+	}
+
+	if on {
+		return b.backlight.Write(sysfs.HIGH)
+	}
+
+	return b.backlight.Write(sysfs.LOW)
+}
+
+//FourBitMode always returns false; this backend drives the full D0-D7 bus.
+func (b *GPIO8BitBackend) FourBitMode() bool {
+	return false
+}
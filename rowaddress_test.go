@@ -0,0 +1,33 @@
+package gobotlcd
+
+import "testing"
+
+func TestSetCursorUsesConfiguredRowAddress(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 16, 4, DotSize5x8, WithRowAddresses(RowAddress16Col))
+
+	if err := lcd.SetCursor(2, 2); err != nil {
+		t.Fatalf("SetCursor: %v", err)
+	}
+
+	want := lcdSetDDRAMAddr | (2 + RowAddress16Col[2])
+	got := backend.commands[len(backend.commands)-1]
+	if got != want {
+		t.Errorf("SetCursor(2, 2) command = %#x, want %#x", got, want)
+	}
+}
+
+func TestSetCursorDefaultsToRowAddress20Col(t *testing.T) {
+	backend := newFakeBackend()
+	lcd := New(backend, 20, 4, DotSize5x8)
+
+	if err := lcd.SetCursor(2, 2); err != nil {
+		t.Fatalf("SetCursor: %v", err)
+	}
+
+	want := lcdSetDDRAMAddr | (2 + RowAddress20Col[2])
+	got := backend.commands[len(backend.commands)-1]
+	if got != want {
+		t.Errorf("SetCursor(2, 2) command = %#x, want %#x", got, want)
+	}
+}